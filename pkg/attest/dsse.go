@@ -0,0 +1,27 @@
+package attest
+
+import "fmt"
+
+// PayloadType identifies an in-toto Statement as the DSSE payload, per
+// https://github.com/in-toto/attestation.
+const PayloadType = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE envelope: a payload plus one or more signatures over
+// its PAE encoding, per https://github.com/secure-systems-lab/dsse.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"` // base64-encoded
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a single DSSE signature over an Envelope's payload.
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"` // base64-encoded
+}
+
+// pae computes the DSSE Pre-Authentication Encoding of a payload:
+// "DSSEv1" SP len(payloadType) SP payloadType SP len(payload) SP payload
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
@@ -0,0 +1,83 @@
+package attest
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPAE(t *testing.T) {
+	cases := []struct {
+		name        string
+		payloadType string
+		payload     []byte
+		want        string
+	}{
+		{"simple", "text", []byte("ab"), "DSSEv1 4 text 2 ab"},
+		{"empty payload", "x", []byte(""), "DSSEv1 1 x 0 "},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := string(pae(c.payloadType, c.payload))
+			if got != c.want {
+				t.Errorf("pae(%q, %q) = %q, want %q", c.payloadType, c.payload, got, c.want)
+			}
+		})
+	}
+}
+
+// TestSignVerifiesWithEd25519 signs a statement and checks the envelope's
+// signature verifies against the PAE encoding of its own payload, the
+// property a DSSE verifier actually relies on.
+func TestSignVerifiesWithEd25519(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(file, []byte("content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	statement, err := NewStatement([]string{file}, "https://example.com/predicate/v1", map[string]string{"k": "v"})
+	if err != nil {
+		t.Fatalf("NewStatement: %v", err)
+	}
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	env, err := Sign(statement, Ed25519Signer{KeyID: "test-key", Key: priv})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if env.PayloadType != PayloadType {
+		t.Errorf("PayloadType = %q, want %q", env.PayloadType, PayloadType)
+	}
+	if len(env.Signatures) != 1 {
+		t.Fatalf("len(Signatures) = %d, want 1", len(env.Signatures))
+	}
+	if env.Signatures[0].KeyID != "test-key" {
+		t.Errorf("KeyID = %q, want test-key", env.Signatures[0].KeyID)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(env.Signatures[0].Sig)
+	if err != nil {
+		t.Fatalf("decode signature: %v", err)
+	}
+	if !ed25519.Verify(pub, pae(PayloadType, payload), sig) {
+		t.Error("signature does not verify against the PAE encoding of its own payload")
+	}
+}
+
+func TestKeylessSignerRefusesWithoutTransport(t *testing.T) {
+	_, _, err := KeylessSigner{}.Sign([]byte("payload"))
+	if err == nil {
+		t.Fatal("KeylessSigner.Sign with no Fulcio/Rekor configured: want error, got nil")
+	}
+}
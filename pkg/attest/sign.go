@@ -0,0 +1,88 @@
+package attest
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// Signer produces a signature over a DSSE pre-authentication-encoded
+// payload and identifies the key that produced it.
+type Signer interface {
+	Sign(payload []byte) (sig []byte, keyID string, err error)
+}
+
+// Ed25519Signer signs with a raw ed25519 private key, e.g. one loaded
+// from a key file with -key.
+type Ed25519Signer struct {
+	KeyID string
+	Key   ed25519.PrivateKey
+}
+
+func (s Ed25519Signer) Sign(payload []byte) ([]byte, string, error) {
+	return ed25519.Sign(s.Key, payload), s.KeyID, nil
+}
+
+// KeylessSigner signs via Sigstore's Fulcio/Rekor keyless flow: Fulcio
+// issues a short-lived certificate against an OIDC identity token and
+// Rekor timestamps the signature in its transparency log. Wiring that up
+// requires an OIDC token source and network access to both services,
+// neither of which this package assumes. Sign always returns an error
+// rather than producing an unverifiable attestation; FulcioURL and
+// RekorURL are not read.
+type KeylessSigner struct {
+	FulcioURL string
+	RekorURL  string
+}
+
+func (s KeylessSigner) Sign(payload []byte) ([]byte, string, error) {
+	return nil, "", fmt.Errorf("keyless signing requires a configured Fulcio/Rekor transport (none configured)")
+}
+
+// LoadEd25519KeyFile reads a PEM-encoded PKCS#8 ed25519 private key, as
+// produced by `openssl genpkey -algorithm ed25519`.
+func LoadEd25519KeyFile(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key in %s: %w", path, err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain an ed25519 private key", path)
+	}
+	return edKey, nil
+}
+
+// Sign wraps statement in a DSSE envelope, signing its PAE encoding with
+// signer.
+func Sign(statement *Statement, signer Signer) (*Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling statement: %w", err)
+	}
+
+	sig, keyID, err := signer.Sign(pae(PayloadType, payload))
+	if err != nil {
+		return nil, fmt.Errorf("signing attestation: %w", err)
+	}
+
+	return &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{
+			{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)},
+		},
+	}, nil
+}
@@ -0,0 +1,78 @@
+package crypto
+
+import "testing"
+
+func TestRegexBackendNumericThreshold(t *testing.T) {
+	rule := DetectionRule{
+		Pattern:    `(?:pbkdf2_hmac\(\s*[^,]+,\s*[^,]+,\s*[^,]+,\s*(\d+)|PBEKeySpec\(\s*[^,]+,\s*[^,]+,\s*(\d+)|crypto\.pbkdf2(?:Sync)?\(\s*[^,]+,\s*[^,]+,\s*(\d+))`,
+		NumericMin: 600000,
+	}
+
+	cases := []struct {
+		name      string
+		line      string
+		wantMatch bool
+	}{
+		{"Java below threshold", `PBEKeySpec spec = new PBEKeySpec(password, salt, 1000, 256);`, true},
+		{"Java at threshold, key length must not be mistaken for it", `new PBEKeySpec(password, salt, 650000, 256);`, false},
+		{"Node below threshold with trailing digest arg", `crypto.pbkdf2Sync(password, salt, 1000, 64, 'sha512');`, true},
+		{"Node above threshold with trailing digest arg", `crypto.pbkdf2Sync(password, salt, 600000, 64, 'sha512');`, false},
+		{"Python above threshold", `hashlib.pbkdf2_hmac('sha256', password, salt, 600000)`, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			file := &File{Lines: []string{c.line}}
+			matches := RegexBackend{}.Match(file, rule)
+			if got := len(matches) > 0; got != c.wantMatch {
+				t.Errorf("Match(%q) matched = %v, want %v", c.line, got, c.wantMatch)
+			}
+		})
+	}
+}
+
+func TestCallArgBelowThreshold(t *testing.T) {
+	below := 128
+	above := 4096
+
+	cases := []struct {
+		name     string
+		call     Call
+		argIndex int
+		min      int
+		want     bool
+	}{
+		{"below min", Call{Args: []*int{&below}}, 0, 2048, true},
+		{"at or above min", Call{Args: []*int{&above}}, 0, 2048, false},
+		{"unresolved argument never matches", Call{Args: []*int{nil}}, 0, 2048, false},
+		{"out of range argument never matches", Call{Args: []*int{&below}}, 1, 2048, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := callArgBelowThreshold(c.call, c.argIndex, c.min); got != c.want {
+				t.Errorf("callArgBelowThreshold() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestAstPredicateMatchesAlternatives(t *testing.T) {
+	cases := []struct {
+		predicate string
+		name      string
+		want      bool
+	}{
+		{"*.initialize|*.Init", "keyGen.initialize", true},
+		{"*.initialize|*.Init", "gen.Init", true},
+		{"*.initialize|*.Init", "gen.init", false},
+		{"rsa.GenerateKey", "rsa.GenerateKey", true},
+		{"rsa.GenerateKey", "rsa.GenerateKeyPair", false},
+	}
+
+	for _, c := range cases {
+		if got := astPredicateMatches(c.predicate, c.name); got != c.want {
+			t.Errorf("astPredicateMatches(%q, %q) = %v, want %v", c.predicate, c.name, got, c.want)
+		}
+	}
+}
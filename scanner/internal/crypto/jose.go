@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// joseFields are the JWT/JWS/JWE header and JWK members JOSEBackend looks
+// for while walking a parsed JSON document.
+var joseFields = map[string]bool{"alg": true, "enc": true, "kty": true}
+
+// JOSEField is a single alg/enc/kty member JOSEBackend found while
+// walking a JSON document, with the line it appears on in the raw file
+// (found by text search, since encoding/json discards positions).
+type JOSEField struct {
+	Key   string
+	Value string
+	Line  int
+}
+
+// JOSEBackend parses JSON JWT/JWS/JWE headers and JWK key files and
+// matches rules against the semantic value of their alg/enc/kty members,
+// rather than against the header as opaque text. Rules whose
+// ContentType isn't "JSON" fall back to RegexBackend against the same
+// file's lines.
+type JOSEBackend struct{}
+
+func (JOSEBackend) Parse(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := &File{Path: path, Lines: strings.Split(string(content), "\n")}
+
+	var doc interface{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		// Not valid JSON (or a .jwt compact-serialization token rather
+		// than a JSON header) — regex-only rules can still run against
+		// the raw text.
+		return file, nil
+	}
+
+	file.JOSEFields = collectJOSEFields(doc, file.Lines)
+	return file, nil
+}
+
+func (JOSEBackend) Match(file *File, rule DetectionRule) []Match {
+	if rule.ContentType != "JSON" || rule.JSONField == "" {
+		return RegexBackend{}.Match(file, rule)
+	}
+
+	re := rule.compiledPattern()
+	if re == nil {
+		return nil
+	}
+
+	var matches []Match
+	for _, field := range file.JOSEFields {
+		if field.Key == rule.JSONField && re.MatchString(field.Value) {
+			matches = append(matches, Match{Line: field.Line})
+		}
+	}
+	return matches
+}
+
+// collectJOSEFields walks a decoded JSON document (maps, slices, and
+// scalars from encoding/json) collecting every alg/enc/kty string member,
+// however deeply nested — under a JWE's "header", a JWK set's "keys", etc.
+func collectJOSEFields(v interface{}, lines []string) []JOSEField {
+	var fields []JOSEField
+	switch node := v.(type) {
+	case map[string]interface{}:
+		for key, value := range node {
+			if joseFields[key] {
+				if s, ok := value.(string); ok {
+					fields = append(fields, JOSEField{Key: key, Value: s, Line: findFieldLine(lines, key, s)})
+				}
+			}
+			fields = append(fields, collectJOSEFields(value, lines)...)
+		}
+	case []interface{}:
+		for _, item := range node {
+			fields = append(fields, collectJOSEFields(item, lines)...)
+		}
+	}
+	return fields
+}
+
+// findFieldLine returns the 1-based line number of the first line
+// containing both the quoted key and the quoted value, or 0 if no line
+// does (e.g. the key and its value are split across lines).
+func findFieldLine(lines []string, key, value string) int {
+	needleKey := fmt.Sprintf("%q", key)
+	needleValue := fmt.Sprintf("%q", value)
+	for i, line := range lines {
+		if strings.Contains(line, needleKey) && strings.Contains(line, needleValue) {
+			return i + 1
+		}
+	}
+	return 0
+}
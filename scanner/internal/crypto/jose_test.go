@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestJOSEBackendMatchesNestedAlgMember(t *testing.T) {
+	path := writeTempFile(t, "jwe.json", `{
+  "protected": {"alg": "RSA-OAEP-256", "enc": "A256GCM"}
+}`)
+
+	file, err := JOSEBackend{}.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	rule := DetectionRule{ContentType: "JSON", JSONField: "alg", Pattern: `^(RSA-OAEP(-256)?|RSA1_5)$`}
+	matches := JOSEBackend{}.Match(file, rule)
+	if len(matches) != 1 {
+		t.Fatalf("Match() = %v, want 1 match on the nested alg member", matches)
+	}
+	if matches[0].Line != 2 {
+		t.Errorf("Match()[0].Line = %d, want 2", matches[0].Line)
+	}
+}
+
+func TestJOSEBackendIgnoresNonMatchingField(t *testing.T) {
+	path := writeTempFile(t, "jwk.json", `{"kty": "oct", "k": "secret"}`)
+
+	file, err := JOSEBackend{}.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	rule := DetectionRule{ContentType: "JSON", JSONField: "kty", Pattern: `^(RSA|EC)$`}
+	matches := JOSEBackend{}.Match(file, rule)
+	if len(matches) != 0 {
+		t.Errorf("Match() = %v, want no matches for kty=oct", matches)
+	}
+}
+
+func TestJOSEBackendFallsBackToRegexForNonJSONRules(t *testing.T) {
+	path := writeTempFile(t, "notes.json", `{"comment": "uses RSA.encrypt somewhere"}`)
+
+	file, err := JOSEBackend{}.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	rule := DetectionRule{Pattern: `RSA\.encrypt`}
+	matches := JOSEBackend{}.Match(file, rule)
+	if len(matches) != 1 {
+		t.Fatalf("Match() = %v, want 1 regex match on the raw line", matches)
+	}
+}
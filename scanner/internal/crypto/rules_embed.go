@@ -0,0 +1,57 @@
+package crypto
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed rules/*.yaml
+var embeddedRules embed.FS
+
+// DefaultRules loads the scanner's built-in rule catalog from the YAML
+// files embedded from rules/. A failure here means an embedded file
+// doesn't parse, which can only happen if the catalog itself is broken,
+// so it panics rather than making every NewScanner caller check an error
+// that a successful build already rules out.
+func DefaultRules() []DetectionRule {
+	sub, err := fs.Sub(embeddedRules, "rules")
+	if err != nil {
+		panic(fmt.Sprintf("crypto: embedded rules/ directory missing: %v", err))
+	}
+	rules, err := LoadRules(sub)
+	if err != nil {
+		panic(fmt.Sprintf("crypto: embedded rule catalog failed to load: %v", err))
+	}
+	return rules
+}
+
+// LoadRules reads every *.yaml file at the root of fsys and decodes it as
+// a list of DetectionRules, concatenating them in filename order. It
+// backs both DefaultRules (embeddedRules) and -rules <dir>
+// (os.DirFS(dir)), so a user can layer organization-specific YAML files
+// on top of the built-in catalog using the same file format.
+func LoadRules(fsys fs.FS) ([]DetectionRule, error) {
+	names, err := fs.Glob(fsys, "*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var rules []DetectionRule
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		var fileRules []DetectionRule
+		if err := yaml.Unmarshal(data, &fileRules); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", name, err)
+		}
+		rules = append(rules, fileRules...)
+	}
+	return rules, nil
+}
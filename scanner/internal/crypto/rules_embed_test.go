@@ -0,0 +1,108 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDefaultRulesLoadsEmbeddedCatalog(t *testing.T) {
+	rules := DefaultRules()
+	if len(rules) == 0 {
+		t.Fatal("DefaultRules() returned no rules")
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.ID == "" {
+			t.Errorf("rule %+v has an empty ID", rule)
+			continue
+		}
+		if seen[rule.ID] {
+			t.Errorf("duplicate rule ID %q", rule.ID)
+		}
+		seen[rule.ID] = true
+	}
+}
+
+func TestLoadRulesParsesYAMLFields(t *testing.T) {
+	fsys := fstest.MapFS{
+		"custom.yaml": &fstest.MapFile{Data: []byte(`
+- id: custom-test-rule
+  algorithm_type: Custom
+  algorithm: TestAlgo
+  method: Function Name
+  pattern: "TestAlgo\\.doThing"
+  risk: Low
+  vulnerability_type: Custom
+  description: test rule
+  recommendation: test recommendation
+  references:
+    - NIST SP 800-208
+  cwe: CWE-1234
+`)},
+	}
+
+	rules, err := LoadRules(fsys)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("len(rules) = %d, want 1", len(rules))
+	}
+
+	rule := rules[0]
+	if rule.ID != "custom-test-rule" {
+		t.Errorf("ID = %q, want custom-test-rule", rule.ID)
+	}
+	if rule.Pattern != `TestAlgo\.doThing` {
+		t.Errorf("Pattern = %q, want TestAlgo\\.doThing", rule.Pattern)
+	}
+	if len(rule.References) != 1 || rule.References[0] != "NIST SP 800-208" {
+		t.Errorf("References = %v, want [\"NIST SP 800-208\"]", rule.References)
+	}
+	if rule.CWE != "CWE-1234" {
+		t.Errorf("CWE = %q, want CWE-1234", rule.CWE)
+	}
+}
+
+func TestLoadRulesRejectsInvalidYAML(t *testing.T) {
+	fsys := fstest.MapFS{
+		"broken.yaml": &fstest.MapFile{Data: []byte("- id: [unterminated")},
+	}
+	if _, err := LoadRules(fsys); err == nil {
+		t.Fatal("LoadRules with malformed YAML: want error, got nil")
+	}
+}
+
+func TestLoadRulesFromDirLayersOnDefaultRules(t *testing.T) {
+	dir := t.TempDir()
+	custom := `
+- id: custom-dir-rule
+  algorithm_type: Custom
+  algorithm: TestAlgo
+  method: Function Name
+  pattern: "TestAlgo"
+  risk: Low
+  vulnerability_type: Custom
+  description: test
+  recommendation: test
+`
+	if err := os.WriteFile(filepath.Join(dir, "custom.yaml"), []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	extra, err := LoadRules(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("LoadRules(os.DirFS): %v", err)
+	}
+	if len(extra) != 1 || extra[0].ID != "custom-dir-rule" {
+		t.Fatalf("LoadRules(os.DirFS) = %+v, want one custom-dir-rule entry", extra)
+	}
+
+	combined := append(DefaultRules(), extra...)
+	if len(combined) != len(DefaultRules())+1 {
+		t.Errorf("len(combined) = %d, want len(DefaultRules())+1", len(combined))
+	}
+}
@@ -8,6 +8,20 @@ import (
 	"strings"
 )
 
+// compiledPattern lazily compiles a rule's regex Pattern. Rules with an
+// empty Pattern (pure AST predicates) compile to nil and never match via
+// RegexBackend.
+func (r DetectionRule) compiledPattern() *regexp.Regexp {
+	if r.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
 // Result represents a vulnerability finding
 type Result struct {
 	File              string `json:"file"`
@@ -19,139 +33,110 @@ type Result struct {
 	VulnerabilityType string `json:"vulnerability_type"` // What type of quantum vulnerability (Shor's, Grover's, etc.)
 	Description       string `json:"description"`        // Description of the vulnerability
 	Recommendation    string `json:"recommendation"`     // Recommendation for remediation
+
+	// CBOM metadata, copied from the DetectionRule that produced this
+	// Result so downstream output formats (see utils.OutputCBOM) don't
+	// need to look the rule back up.
+	AssetType                string `json:"asset_type,omitempty"`                  // CycloneDX cryptographic-asset type: algorithm, protocol, certificate, related-crypto-material
+	Primitive                string `json:"primitive,omitempty"`                   // CycloneDX crypto primitive: pke, signature, kem, block-cipher, hash, kdf
+	OID                      string `json:"oid,omitempty"`                         // dotted-decimal object identifier, when known
+	NistQuantumSecurityLevel int    `json:"nist_quantum_security_level,omitempty"` // 0 when not applicable/known
 }
 
-// DetectionRule defines a pattern to detect vulnerable crypto
+// DetectionRule defines a pattern to detect vulnerable crypto. A rule
+// matches via Pattern (a regex evaluated against source lines) or, for
+// languages with an AST backend, via ASTPredicate (a call expression
+// predicate evaluated against parsed calls) — see Backend. The built-in
+// catalog is loaded from the YAML files in rules/ (see rules_embed.go and
+// LoadRules); the yaml tags below are that file format.
 type DetectionRule struct {
-	AlgorithmType     string
-	AlgorithmName     string
-	Method            string
-	Pattern           string
-	RiskLevel         string
-	VulnerabilityType string
-	Description       string
-	Recommendation    string
+	ID                string `yaml:"id"` // stable identifier, e.g. "rsa-function-name"; used by -disable
+	AlgorithmType     string `yaml:"algorithm_type"`
+	AlgorithmName     string `yaml:"algorithm"`
+	Method            string `yaml:"method"`
+	Pattern           string `yaml:"pattern"`
+	ASTPredicate      string `yaml:"ast"` // e.g. "rsa.GenerateKey" or "*.initialize"; empty means regex-only
+	RiskLevel         string `yaml:"risk"`
+	VulnerabilityType string `yaml:"vulnerability_type"`
+	Description       string `yaml:"description"`
+	Recommendation    string `yaml:"recommendation"`
+
+	// ContentType selects how JOSEBackend matches this rule: "" (the
+	// default) means Pattern/ASTPredicate is matched against lines or
+	// calls. "JSON" means that, in files JOSEBackend parses, Pattern is
+	// matched against the value of a specific JSON member (JSONField)
+	// instead. Every rule runs against every scanned file; the other
+	// backends ignore ContentType and match Pattern against raw lines.
+	ContentType string `yaml:"content_type"`
+	JSONField   string `yaml:"json_field"` // JSON member Pattern is matched against, e.g. "alg", "enc", "kty"
+
+	// NumericMin, when non-zero, turns this into a numeric-threshold rule:
+	// it only matches when the integer found alongside Pattern is below
+	// NumericMin (e.g. a PBKDF2 iteration count, a bcrypt cost factor).
+	// For RegexBackend this is Pattern's last non-empty capture group; for
+	// an AST backend it's call.Args[NumericArg].
+	NumericArg int `yaml:"numeric_arg"`
+	NumericMin int `yaml:"numeric_min"`
+
+	// CBOM metadata, rendered by utils.OutputCBOM as CycloneDX
+	// cryptoProperties. Optional: zero values are simply omitted from
+	// the generated document.
+	AssetType                string `yaml:"asset_type"` // algorithm, protocol, certificate, related-crypto-material
+	Primitive                string `yaml:"primitive"`  // pke, signature, kem, block-cipher, hash, kdf
+	OID                      string `yaml:"oid"`        // dotted-decimal object identifier, e.g. "1.2.840.113549.1.1.1"
+	NistQuantumSecurityLevel int    `yaml:"nist_quantum_security_level"`
+
+	// References and CWE are informational citations surfaced by
+	// -list-rules: standards (NIST SP 800-208, FIPS 203/204/205) and the
+	// CWE ID the finding corresponds to, when one applies.
+	References []string `yaml:"references"`
+	CWE        string   `yaml:"cwe"`
 }
 
 // Scanner handles the scanning process
 type Scanner struct {
 	Verbose bool
 	Rules   []DetectionRule
+	// Backends maps a lowercased file extension (e.g. ".go") to the
+	// Backend used to parse and match files with that extension.
+	// Extensions without an entry use RegexBackend.
+	Backends map[string]Backend
 }
 
-// NewScanner creates a new scanner instance
+// NewScanner creates a new scanner instance using the built-in rule
+// catalog embedded from rules/ (see rules_embed.go).
 func NewScanner(verbose bool) *Scanner {
+	return NewScannerWithRules(verbose, DefaultRules())
+}
+
+// NewScannerWithRules creates a new scanner instance with an explicit
+// rule set, so callers (see -rules and -disable in scanner/main.go) can
+// layer their own rules on top of, or trim down, DefaultRules().
+func NewScannerWithRules(verbose bool, rules []DetectionRule) *Scanner {
 	return &Scanner{
 		Verbose: verbose,
-		Rules: []DetectionRule{
-			{
-				"PublicKey", "RSA", "Function Name",
-				`RSA\.encrypt|RSACipher|rsa\.newkeys|KeyPairGenerator\.getInstance\("RSA"\)|crypto\.generateKeyPairSync\('rsa'`,
-				"High",
-				"Shor's Algorithm",
-				"RSA encryption is vulnerable to quantum attacks using Shor's algorithm, which can factor large integers in polynomial time",
-				"Replace with quantum-resistant algorithm ML-KEM (CRYSTALS-Kyber) for key encapsulation or consider hybrid approaches",
-			},
-			{
-				"PublicKey", "RSA", "Import Statement",
-				`from cryptography\.hazmat\.primitives\.asymmetric import rsa|import rsa|import java.security.KeyPairGenerator|const crypto = require\('crypto'\)`,
-				"High",
-				"Shor's Algorithm",
-				"RSA cryptography libraries are vulnerable to quantum attacks using Shor's algorithm",
-				"Replace with NIST-standardized post-quantum cryptography libraries using ML-KEM",
-			},
-			{
-				"PublicKey", "RSA", "Configuration",
-				`algorithm = "RSA"|keyGen\.initialize\(2048\)`,
-				"High",
-				"Shor's Algorithm",
-				"RSA key generation with any key size is vulnerable to quantum attacks",
-				"Replace with ML-KEM (CRYSTALS-Kyber) with appropriate parameter sets",
-			},
-			{
-				"SymmetricKey", "AES-128", "Function Name",
-				`AES\.encrypt|AESCipher|Cipher\.getInstance\("AES|crypto\.createCipheriv\('aes-128-cbc'`,
-				"Medium",
-				"Grover's Algorithm",
-				"AES-128 provides only 64 bits of security against quantum attacks using Grover's algorithm",
-				"Upgrade to AES-256 which provides adequate security against known quantum attacks",
-			},
-			{
-				"SymmetricKey", "AES-128", "Import Statement",
-				`from cryptography\.hazmat\.primitives\.ciphers import Cipher, algorithms|import javax.crypto.Cipher|const crypto = require\('crypto'\)`,
-				"Medium",
-				"Grover's Algorithm",
-				"Symmetric encryption libraries that use AES-128 by default offer reduced security against quantum attacks",
-				"Explicitly configure the library to use AES-256 instead of AES-128",
-			},
-			{
-				"SymmetricKey", "AES-128", "Configuration",
-				`cipher = "AES"|algorithms\.AES\(key_128|KeyGenerator\.getInstance\("AES"\)\.init\(128\)`,
-				"Medium",
-				"Grover's Algorithm",
-				"AES with 128-bit key size provides inadequate security against quantum computers",
-				"Increase key size to 256 bits (AES-256) to maintain adequate security margin",
-			},
-			{
-				"PublicKey", "ECC", "Function Name",
-				`ECDSA\.sign|ECCCipher|SigningKey\.generate`,
-				"High",
-				"Shor's Algorithm",
-				"Elliptic Curve Cryptography is vulnerable to quantum attacks using a variant of Shor's algorithm",
-				"Replace with quantum-resistant ML-DSA (CRYSTALS-Dilithium) or SLH-DSA (SPHINCS+) for digital signatures",
-			},
-			{
-				"PublicKey", "ECC", "Import Statement",
-				`from cryptography\.hazmat\.primitives\.asymmetric import ec|from ecdsa import SigningKey`,
-				"High",
-				"Shor's Algorithm",
-				"Elliptic Curve Cryptography libraries are vulnerable to quantum attacks",
-				"Replace with post-quantum signature schemes like ML-DSA or SLH-DSA",
-			},
-			{
-				"PublicKey", "ECC", "Configuration",
-				`curve = "secp256r1"|curve = "prime256v1"`,
-				"High",
-				"Shor's Algorithm",
-				"All ECC curves are vulnerable to quantum computing attacks regardless of size",
-				"Replace with quantum-resistant signature schemes like ML-DSA (CRYSTALS-Dilithium)",
-			},
-			{
-				"PublicKey", "DH", "Function Name",
-				`DHParameterSpec|DHGenParameterSpec|DiffieHellmanGroup|createDiffieHellman`,
-				"High",
-				"Shor's Algorithm",
-				"Diffie-Hellman key exchange is vulnerable to quantum attacks via the discrete logarithm problem",
-				"Replace with ML-KEM (CRYSTALS-Kyber) for quantum-resistant key exchange",
-			},
-			{
-				"PublicKey", "DH", "Import Statement",
-				`import javax.crypto.spec.DHParameterSpec|const dh = crypto.createDiffieHellman`,
-				"High",
-				"Shor's Algorithm",
-				"Diffie-Hellman library imports indicate vulnerable key exchange methods",
-				"Replace with post-quantum key encapsulation mechanisms like ML-KEM",
-			},
-			{
-				"PostQuantum", "CRYSTALS-Kyber", "Import Statement",
-				`import pqcrypto.kem.kyber|from kyber import Kyber`,
-				"Low",
-				"Quantum-Resistant",
-				"CRYSTALS-Kyber is a NIST-standardized post-quantum key encapsulation mechanism",
-				"Correctly implemented, this algorithm provides resistance to known quantum attacks",
-			},
-			{
-				"PostQuantum", "CRYSTALS-Dilithium", "Import Statement",
-				`import pqcrypto.sign.dilithium|from dilithium import Dilithium`,
-				"Low",
-				"Quantum-Resistant",
-				"CRYSTALS-Dilithium is a NIST-standardized post-quantum digital signature algorithm",
-				"Correctly implemented, this algorithm provides resistance to known quantum attacks",
-			},
+		Rules:   rules,
+		Backends: map[string]Backend{
+			".go":   GoASTBackend{},
+			".json": JOSEBackend{},
+			".jwk":  JOSEBackend{},
+			".jwt":  JOSEBackend{},
 		},
 	}
 }
 
+// hasJOSERules reports whether the scanner's rule set includes any JOSE
+// (JWT/JWS/JWE/JWK) rule, used by shouldSkip to decide whether
+// .json/.jwt/.jwk files are in scope at all.
+func (s *Scanner) hasJOSERules() bool {
+	for _, rule := range s.Rules {
+		if rule.AlgorithmType == "JOSE" {
+			return true
+		}
+	}
+	return false
+}
+
 // ScanDirectory scans all files in a directory recursively
 func (s *Scanner) ScanDirectory(dir string) []Result {
 	var results []Result
@@ -199,32 +184,34 @@ func (s *Scanner) ScanFile(filePath string) []Result {
 		return results
 	}
 
-	content, err := os.ReadFile(filePath)
+	backend := s.backendFor(filePath)
+	file, err := backend.Parse(filePath)
 	if err != nil {
 		fmt.Printf("Error reading file %s: %v\n", filePath, err)
 		return results
 	}
 
-	lines := strings.Split(string(content), "\n")
-	for i, line := range lines {
-		for _, rule := range s.Rules {
-			if match, _ := regexp.MatchString(rule.Pattern, line); match {
-				results = append(results, Result{
-					File:              filePath,
-					Algorithm:         rule.AlgorithmName,
-					Type:              rule.AlgorithmType,
-					Line:              i + 1,
-					Method:            rule.Method,
-					Risk:              rule.RiskLevel,
-					VulnerabilityType: rule.VulnerabilityType,
-					Description:       rule.Description,
-					Recommendation:    rule.Recommendation,
-				})
-
-				if s.Verbose {
-					fmt.Printf("Match found: %s (Line %d) Method: %s Risk: %s\n",
-						rule.AlgorithmName, i+1, rule.Method, rule.RiskLevel)
-				}
+	for _, rule := range s.Rules {
+		for _, m := range backend.Match(file, rule) {
+			results = append(results, Result{
+				File:                     filePath,
+				Algorithm:                rule.AlgorithmName,
+				Type:                     rule.AlgorithmType,
+				Line:                     m.Line,
+				Method:                   rule.Method,
+				Risk:                     rule.RiskLevel,
+				VulnerabilityType:        rule.VulnerabilityType,
+				Description:              rule.Description,
+				Recommendation:           rule.Recommendation,
+				AssetType:                rule.AssetType,
+				Primitive:                rule.Primitive,
+				OID:                      rule.OID,
+				NistQuantumSecurityLevel: rule.NistQuantumSecurityLevel,
+			})
+
+			if s.Verbose {
+				fmt.Printf("Match found: %s (Line %d) Method: %s Risk: %s\n",
+					rule.AlgorithmName, m.Line, rule.Method, rule.RiskLevel)
 			}
 		}
 	}
@@ -232,25 +219,68 @@ func (s *Scanner) ScanFile(filePath string) []Result {
 	return results
 }
 
+// backendFor returns the Backend registered for filePath's extension,
+// falling back to RegexBackend when no language-specific backend applies.
+func (s *Scanner) backendFor(filePath string) Backend {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if backend, ok := s.Backends[ext]; ok {
+		return backend
+	}
+	return RegexBackend{}
+}
+
+// joseExts are the extensions brought into scope when the scanner's rule
+// set includes a JOSE rule, on top of the extensions ShouldSkip always allows.
+//
+// .yaml/.yml are deliberately excluded: backendFor only maps
+// .json/.jwk/.jwt to JOSEBackend, so a YAML file would fall through to
+// RegexBackend and be matched against JOSE rules' value-only patterns
+// (e.g. "^RS(256|384|512)$") against raw lines like "alg: RS256", which
+// never match. Add them back once a YAML-aware backend exists.
+var joseExts = []string{".json", ".jwt", ".jwk"}
+
 // shouldSkip determines if a file should be skipped during scanning
 func (s *Scanner) shouldSkip(path string) bool {
-	// Skip node_modules, .git, etc.
-	if strings.Contains(path, "node_modules") ||
-		strings.Contains(path, ".git") ||
-		strings.Contains(path, "__pycache__") ||
-		strings.Contains(path, "vendor") {
+	if isExcludedDir(path) {
 		return true
 	}
+	if extAllowed(path, defaultValidExts) {
+		return false
+	}
+	return !(s.hasJOSERules() && extAllowed(path, joseExts))
+}
 
-	// Only scan certain file extensions
-	ext := strings.ToLower(filepath.Ext(path))
-	validExts := []string{".go", ".java", ".js", ".ts", ".py", ".php", ".rb", ".c", ".cpp", ".h", ".cs", ".swift"}
+// ShouldSkip determines if a file should be skipped during scanning under
+// this scanner's active rule set. It is exported so callers that need the
+// scanner's file selection (e.g. pkg/attest's consumer in scanner/main.go,
+// to list the files it attests to) see exactly the files this Scanner
+// would scan — including JOSE-only extensions when the rule set includes
+// a JOSE rule — rather than reimplementing the logic against a
+// rule-set-blind default.
+func (s *Scanner) ShouldSkip(path string) bool {
+	return s.shouldSkip(path)
+}
+
+// defaultValidExts are the source file extensions scanned regardless of
+// which rules are active.
+var defaultValidExts = []string{".go", ".java", ".js", ".ts", ".py", ".php", ".rb", ".c", ".cpp", ".h", ".cs", ".swift"}
 
-	for _, validExt := range validExts {
-		if ext == validExt {
-			return false
+// isExcludedDir reports whether path falls under a directory the scanner
+// never descends into, such as vendored or generated code.
+func isExcludedDir(path string) bool {
+	return strings.Contains(path, "node_modules") ||
+		strings.Contains(path, ".git") ||
+		strings.Contains(path, "__pycache__") ||
+		strings.Contains(path, "vendor")
+}
+
+// extAllowed reports whether path's extension is one of exts.
+func extAllowed(path string, exts []string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, allowed := range exts {
+		if ext == allowed {
+			return true
 		}
 	}
-
-	return true
+	return false
 }
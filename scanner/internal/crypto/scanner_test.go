@@ -0,0 +1,19 @@
+package crypto
+
+import "testing"
+
+func TestShouldSkipExcludesYAMLEvenWithJOSERules(t *testing.T) {
+	scanner := NewScannerWithRules(false, []DetectionRule{
+		{ID: "jose-rule", AlgorithmType: "JOSE", ContentType: "JSON", JSONField: "alg"},
+	})
+
+	if scanner.ShouldSkip("keys.json") {
+		t.Error("ShouldSkip(keys.json) = true, want false with a JOSE rule active")
+	}
+	if !scanner.ShouldSkip("keys.yaml") {
+		t.Error("ShouldSkip(keys.yaml) = false, want true: no backend handles YAML, so it would never produce a match")
+	}
+	if !scanner.ShouldSkip("keys.yml") {
+		t.Error("ShouldSkip(keys.yml) = false, want true: no backend handles YAML, so it would never produce a match")
+	}
+}
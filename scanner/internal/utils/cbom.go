@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"qvs-pro/scanner/internal/crypto"
+)
+
+// CBOMMeta carries the scan-level information that belongs in a CycloneDX
+// document's metadata block rather than on any individual component.
+// OutputCBOM writes ToolName and ToolVersion there; ScanTarget is not
+// written into the document.
+type CBOMMeta struct {
+	ToolName    string
+	ToolVersion string
+	ScanTarget  string
+}
+
+// cbomDocument mirrors the subset of the CycloneDX 1.6 schema this
+// scanner populates: a bom with one cryptographic-asset component per
+// finding. Fields the scanner doesn't have data for are simply omitted.
+type cbomDocument struct {
+	BOMFormat   string          `json:"bomFormat"`
+	SpecVersion string          `json:"specVersion"`
+	Version     int             `json:"version"`
+	Metadata    cbomMetadata    `json:"metadata"`
+	Components  []cbomComponent `json:"components"`
+}
+
+type cbomMetadata struct {
+	Timestamp string     `json:"timestamp"`
+	Tools     []cbomTool `json:"tools"`
+}
+
+type cbomTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type cbomComponent struct {
+	Type             string               `json:"type"`
+	Name             string               `json:"name"`
+	BOMRef           string               `json:"bom-ref"`
+	CryptoProperties cbomCryptoProperties `json:"cryptoProperties"`
+}
+
+type cbomCryptoProperties struct {
+	AssetType           string                   `json:"assetType"`
+	AlgorithmProperties *cbomAlgorithmProperties `json:"algorithmProperties,omitempty"`
+	OID                 string                   `json:"oid,omitempty"`
+}
+
+type cbomAlgorithmProperties struct {
+	Primitive                string `json:"primitive,omitempty"`
+	NISTQuantumSecurityLevel int    `json:"nistQuantumSecurityLevel,omitempty"`
+}
+
+// OutputCBOM renders scan results as a CycloneDX 1.6 Cryptographic Bill
+// of Materials (CBOM) document, one cryptographic-asset component per
+// finding, and prints it to stdout.
+func OutputCBOM(results []crypto.Result, meta CBOMMeta) {
+	doc := cbomDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.6",
+		Version:     1,
+		Metadata: cbomMetadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools: []cbomTool{
+				{Name: meta.ToolName, Version: meta.ToolVersion},
+			},
+		},
+	}
+
+	for i, result := range results {
+		assetType := result.AssetType
+		if assetType == "" {
+			assetType = "algorithm"
+		}
+
+		component := cbomComponent{
+			Type:   "cryptographic-asset",
+			Name:   fmt.Sprintf("%s (%s:%d)", result.Algorithm, result.File, result.Line),
+			BOMRef: fmt.Sprintf("crypto-asset-%d", i+1),
+			CryptoProperties: cbomCryptoProperties{
+				AssetType: assetType,
+				OID:       result.OID,
+			},
+		}
+		if result.Primitive != "" || result.NistQuantumSecurityLevel != 0 {
+			component.CryptoProperties.AlgorithmProperties = &cbomAlgorithmProperties{
+				Primitive:                result.Primitive,
+				NISTQuantumSecurityLevel: result.NistQuantumSecurityLevel,
+			}
+		}
+		doc.Components = append(doc.Components, component)
+	}
+
+	jsonData, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		fmt.Printf("Error converting to CBOM: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(jsonData))
+}
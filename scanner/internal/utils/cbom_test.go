@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"qvs-pro/scanner/internal/crypto"
+)
+
+// captureStdout redirects os.Stdout for the duration of fn and returns
+// whatever was written to it; OutputCBOM/OutputSARIF/OutputText print
+// directly rather than returning a value, so this is the only way to
+// exercise them.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	old := os.Stdout
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		t.Fatalf("reading captured stdout: %v", err)
+	}
+	return buf.String()
+}
+
+func TestOutputCBOM(t *testing.T) {
+	results := []crypto.Result{
+		{
+			File: "a.go", Algorithm: "RSA", Line: 10,
+			AssetType: "algorithm", Primitive: "pke", OID: "1.2.840.113549.1.1.1",
+		},
+		{
+			File: "b.go", Algorithm: "AES-128", Line: 5,
+			Primitive: "block-cipher", NistQuantumSecurityLevel: 1,
+		},
+	}
+
+	out := captureStdout(t, func() {
+		OutputCBOM(results, CBOMMeta{ToolName: "qvs-pro", ToolVersion: "1.0.0", ScanTarget: "/tmp"})
+	})
+
+	var doc struct {
+		BOMFormat   string `json:"bomFormat"`
+		SpecVersion string `json:"specVersion"`
+		Components  []struct {
+			CryptoProperties struct {
+				AssetType           string `json:"assetType"`
+				OID                 string `json:"oid"`
+				AlgorithmProperties *struct {
+					Primitive                string `json:"primitive"`
+					NISTQuantumSecurityLevel int    `json:"nistQuantumSecurityLevel"`
+				} `json:"algorithmProperties"`
+			} `json:"cryptoProperties"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("unmarshal CBOM output: %v\noutput: %s", err, out)
+	}
+
+	if doc.BOMFormat != "CycloneDX" {
+		t.Errorf("BOMFormat = %q, want CycloneDX", doc.BOMFormat)
+	}
+	if doc.SpecVersion != "1.6" {
+		t.Errorf("SpecVersion = %q, want 1.6", doc.SpecVersion)
+	}
+	if len(doc.Components) != 2 {
+		t.Fatalf("len(Components) = %d, want 2", len(doc.Components))
+	}
+	if doc.Components[0].CryptoProperties.OID != "1.2.840.113549.1.1.1" {
+		t.Errorf("Components[0].OID = %q, want the RSA OID", doc.Components[0].CryptoProperties.OID)
+	}
+	if doc.Components[1].CryptoProperties.AssetType != "algorithm" {
+		t.Errorf("Components[1].AssetType = %q, want the default %q", doc.Components[1].CryptoProperties.AssetType, "algorithm")
+	}
+	if doc.Components[1].CryptoProperties.AlgorithmProperties == nil || doc.Components[1].CryptoProperties.AlgorithmProperties.NISTQuantumSecurityLevel != 1 {
+		t.Errorf("Components[1].AlgorithmProperties = %+v, want NISTQuantumSecurityLevel 1", doc.Components[1].CryptoProperties.AlgorithmProperties)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"qvs-pro/scanner/internal/crypto"
 )
@@ -19,6 +20,26 @@ func OutputJSON(results interface{}) {
 	fmt.Println(string(jsonData))
 }
 
+// OutputRuleList prints a scanner's resolved rule catalog, one entry per
+// rule, for the -list-rules flag — so a user can see exactly which rule
+// IDs are active (and available to -disable) before running a scan.
+func OutputRuleList(rules []crypto.DetectionRule) {
+	fmt.Printf("%d rules:\n\n", len(rules))
+	for _, rule := range rules {
+		fmt.Printf("ID: %s\n", rule.ID)
+		fmt.Printf("Algorithm: %s (%s)\n", rule.AlgorithmName, rule.AlgorithmType)
+		fmt.Printf("Method: %s\n", rule.Method)
+		fmt.Printf("Risk Level: %s\n", rule.RiskLevel)
+		if rule.CWE != "" {
+			fmt.Printf("CWE: %s\n", rule.CWE)
+		}
+		if len(rule.References) > 0 {
+			fmt.Printf("References: %s\n", strings.Join(rule.References, ", "))
+		}
+		fmt.Println("----------------------")
+	}
+}
+
 // OutputText outputs scan results in human-readable text format
 func OutputText(results interface{}) {
 	// Type assertion to access the Result struct fields
@@ -0,0 +1,204 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"qvs-pro/scanner/internal/crypto"
+)
+
+// nistPQCGuidanceURL is linked from every rule's helpUri so a reviewer
+// landing on a SARIF finding in GitHub/GitLab code scanning has a path to
+// NIST's migration guidance.
+const nistPQCGuidanceURL = "https://csrc.nist.gov/projects/post-quantum-cryptography"
+
+// SARIFMeta carries the scan-level information OutputSARIF needs beyond
+// the results themselves: the tool's own identity, and the root results'
+// File paths should be made relative to.
+type SARIFMeta struct {
+	ToolName    string
+	ToolVersion string
+	ScanRoot    string
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string                `json:"name"`
+	Version string                `json:"version"`
+	Rules   []sarifRuleDescriptor `json:"rules"`
+}
+
+type sarifRuleDescriptor struct {
+	ID                   string          `json:"id"`
+	ShortDescription     sarifText       `json:"shortDescription"`
+	HelpURI              string          `json:"helpUri"`
+	DefaultConfiguration sarifRuleConfig `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifText         `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	Fixes               []sarifFix        `json:"fixes,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifFix struct {
+	Description sarifText `json:"description"`
+}
+
+// OutputSARIF renders scan results as a SARIF 2.1.0 log with one run, so
+// the scanner can be dropped into GitHub/GitLab code-scanning pipelines.
+func OutputSARIF(results []crypto.Result, meta SARIFMeta) {
+	driver := sarifDriver{
+		Name:    meta.ToolName,
+		Version: meta.ToolVersion,
+	}
+
+	seenRules := make(map[string]bool)
+	sarifResults := make([]sarifResult, 0, len(results))
+
+	for _, result := range results {
+		ruleID := sarifRuleID(result.Algorithm, result.Method)
+		if !seenRules[ruleID] {
+			seenRules[ruleID] = true
+			driver.Rules = append(driver.Rules, sarifRuleDescriptor{
+				ID:               ruleID,
+				ShortDescription: sarifText{Text: result.Description},
+				HelpURI:          nistPQCGuidanceURL,
+				DefaultConfiguration: sarifRuleConfig{
+					Level: sarifLevel(result.Risk),
+				},
+			})
+		}
+
+		uri := sarifRelativeURI(result.File, meta.ScanRoot)
+
+		sarifResults = append(sarifResults, sarifResult{
+			RuleID:  ruleID,
+			Level:   sarifLevel(result.Risk),
+			Message: sarifText{Text: result.Description},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: uri},
+					Region:           sarifRegion{StartLine: result.Line},
+				},
+			}},
+			Fixes: []sarifFix{{
+				Description: sarifText{Text: result.Recommendation},
+			}},
+			PartialFingerprints: map[string]string{
+				"primaryLocationLineHash": sarifFingerprint(uri, ruleID, result.Line),
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: driver},
+			Results: sarifResults,
+		}},
+	}
+
+	jsonData, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		fmt.Printf("Error converting to SARIF: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(jsonData))
+}
+
+// sarifRuleID builds a stable rule id from an algorithm/method pair, e.g.
+// ("RSA", "Function Name") -> "QVS-RSA-Function-Name".
+func sarifRuleID(algorithm, method string) string {
+	return "QVS-" + sarifSlug(algorithm) + "-" + sarifSlug(method)
+}
+
+var sarifNonWordRun = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+func sarifSlug(s string) string {
+	return strings.Trim(sarifNonWordRun.ReplaceAllString(s, "-"), "-")
+}
+
+// sarifLevel maps a DetectionRule's RiskLevel to a SARIF result/rule level.
+func sarifLevel(risk string) string {
+	switch risk {
+	case "High":
+		return "error"
+	case "Medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifRelativeURI returns file relative to root when root is set and
+// file is under it, so findings don't embed the scanning machine's
+// absolute paths.
+func sarifRelativeURI(file, root string) string {
+	if root == "" {
+		return file
+	}
+	rel, err := filepath.Rel(root, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
+
+// sarifFingerprint hashes a finding's file, rule and line number so GitHub
+// can dedupe the same finding across scans. The fingerprint changes
+// whenever the finding's own line number does.
+func sarifFingerprint(uri, ruleID string, line int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%d", uri, ruleID, line)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"qvs-pro/scanner/internal/crypto"
+)
+
+func TestOutputSARIF(t *testing.T) {
+	results := []crypto.Result{
+		{
+			File: "/scan/root/src/main.go", Algorithm: "RSA", Type: "PublicKey", Line: 14,
+			Method: "Function Name", Risk: "High", Description: "desc", Recommendation: "rec",
+		},
+		{
+			File: "/scan/root/src/main.go", Algorithm: "AES-128", Type: "SymmetricKey", Line: 20,
+			Method: "Configuration", Risk: "Medium", Description: "desc2", Recommendation: "rec2",
+		},
+	}
+
+	out := captureStdout(t, func() {
+		OutputSARIF(results, SARIFMeta{ToolName: "qvs-pro", ToolVersion: "1.0.0", ScanRoot: "/scan/root"})
+	})
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID                   string `json:"id"`
+						DefaultConfiguration struct {
+							Level string `json:"level"`
+						} `json:"defaultConfiguration"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				PartialFingerprints map[string]string `json:"partialFingerprints"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(out), &log); err != nil {
+		t.Fatalf("unmarshal SARIF output: %v\noutput: %s", err, out)
+	}
+
+	if log.Version != "2.1.0" {
+		t.Errorf("Version = %q, want 2.1.0", log.Version)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("len(Runs) = %d, want 1", len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	if len(run.Tool.Driver.Rules) != 2 {
+		t.Fatalf("len(Rules) = %d, want one rule descriptor per distinct algorithm/method pair", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("len(Results) = %d, want 2", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.Level != "error" {
+		t.Errorf("first result Level = %q, want error for High risk", first.Level)
+	}
+	if first.Locations[0].PhysicalLocation.ArtifactLocation.URI != "src/main.go" {
+		t.Errorf("URI = %q, want path relative to ScanRoot", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+	if first.Locations[0].PhysicalLocation.Region.StartLine != 14 {
+		t.Errorf("StartLine = %d, want 14", first.Locations[0].PhysicalLocation.Region.StartLine)
+	}
+	if first.PartialFingerprints["primaryLocationLineHash"] == "" {
+		t.Error("PartialFingerprints[primaryLocationLineHash] is empty, want a fingerprint")
+	}
+
+	second := run.Results[1]
+	if second.Level != "warning" {
+		t.Errorf("second result Level = %q, want warning for Medium risk", second.Level)
+	}
+}
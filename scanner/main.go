@@ -5,17 +5,31 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"qvs-pro/pkg/attest"
 	"qvs-pro/scanner/internal/crypto"
 	"qvs-pro/scanner/internal/utils"
 )
 
 const version = "1.0.0"
 
+// attestationPredicateType identifies the shape of the predicate a scan
+// attestation carries: the []crypto.Result this scanner produces.
+const attestationPredicateType = "https://qvs-pro.dev/crypto-scan/v0.1"
+
 func main() {
 	// Define command-line flags
 	dirToScan := flag.String("dir", "", "Directory or file to scan (default: current directory)")
 	outputJSON := flag.Bool("json", false, "Output results as JSON")
+	outputCBOM := flag.Bool("cbom", false, "Output results as a CycloneDX Cryptographic Bill of Materials (CBOM)")
+	outputSARIF := flag.Bool("sarif", false, "Output results as a SARIF 2.1.0 log for code-scanning integration")
+	attestFlag := flag.Bool("attest", false, "Sign scan results as a DSSE in-toto attestation and print the envelope")
+	keyPath := flag.String("key", "", "Path to a PEM-encoded ed25519 private key used with -attest")
+	keyless := flag.Bool("keyless", false, "Sign with Sigstore keyless (Fulcio/Rekor) instead of -key (not yet implemented; always errors, see attest.KeylessSigner)")
+	rulesDir := flag.String("rules", "", "Directory of YAML rule files to layer on top of the built-in catalog")
+	disable := flag.String("disable", "", "Comma-separated rule IDs to disable, e.g. -disable rsa-configuration,aes128-configuration")
+	listRules := flag.Bool("list-rules", false, "Print the resolved rule catalog and exit")
 	verbose := flag.Bool("verbose", false, "Enable verbose output")
 	versionFlag := flag.Bool("version", false, "Print the version")
 
@@ -28,6 +42,17 @@ func main() {
 		return
 	}
 
+	rules, err := resolveRules(*rulesDir, *disable)
+	if err != nil {
+		fmt.Printf("Error resolving rules: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *listRules {
+		utils.OutputRuleList(rules)
+		return
+	}
+
 	// If no directory specified, use current directory
 	if *dirToScan == "" {
 		currentDir, err := os.Getwd()
@@ -55,7 +80,7 @@ func main() {
 	}
 
 	var results []crypto.Result
-	scanner := crypto.NewScanner(*verbose)
+	scanner := crypto.NewScannerWithRules(*verbose, rules)
 
 	if fileInfo.IsDir() {
 		results = scanner.ScanDirectory(absPath)
@@ -68,9 +93,109 @@ func main() {
 	}
 
 	// Output results
-	if *outputJSON {
+	switch {
+	case *outputCBOM:
+		utils.OutputCBOM(results, utils.CBOMMeta{
+			ToolName:    "qvs-pro",
+			ToolVersion: version,
+			ScanTarget:  absPath,
+		})
+	case *outputSARIF:
+		scanRoot := absPath
+		if !fileInfo.IsDir() {
+			scanRoot = filepath.Dir(absPath)
+		}
+		utils.OutputSARIF(results, utils.SARIFMeta{
+			ToolName:    "qvs-pro",
+			ToolVersion: version,
+			ScanRoot:    scanRoot,
+		})
+	case *outputJSON:
 		utils.OutputJSON(results)
-	} else {
+	default:
 		utils.OutputText(results)
 	}
+
+	if *attestFlag {
+		env, err := attestResults(scanner, absPath, fileInfo, results, *keyPath, *keyless)
+		if err != nil {
+			fmt.Printf("Error creating attestation: %v\n", err)
+			os.Exit(1)
+		}
+		utils.OutputJSON(env)
+	}
+}
+
+// resolveRules builds the rule set a scan runs with: the built-in catalog,
+// optionally extended with the YAML files in rulesDir (-rules), with any
+// rule IDs named in disable (-disable, comma-separated) removed.
+func resolveRules(rulesDir, disable string) ([]crypto.DetectionRule, error) {
+	rules := crypto.DefaultRules()
+
+	if rulesDir != "" {
+		extra, err := crypto.LoadRules(os.DirFS(rulesDir))
+		if err != nil {
+			return nil, fmt.Errorf("loading -rules %s: %w", rulesDir, err)
+		}
+		rules = append(rules, extra...)
+	}
+
+	if disable == "" {
+		return rules, nil
+	}
+	disabled := make(map[string]bool)
+	for _, id := range strings.Split(disable, ",") {
+		disabled[strings.TrimSpace(id)] = true
+	}
+	filtered := rules[:0]
+	for _, rule := range rules {
+		if !disabled[rule.ID] {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered, nil
+}
+
+// attestResults wraps results in a signed DSSE in-toto attestation whose
+// subjects are every file the scan considered. It walks with scanner's own
+// ShouldSkip rather than a rule-set-blind default, so the subject list
+// matches the files the scan covered, with or without findings — including JSON/JWK/JWT
+// files when the active rule set includes a JOSE rule.
+func attestResults(scanner *crypto.Scanner, scanPath string, scanInfo os.FileInfo, results []crypto.Result, keyPath string, keyless bool) (*attest.Envelope, error) {
+	var scannedFiles []string
+	if scanInfo.IsDir() {
+		err := filepath.Walk(scanPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || scanner.ShouldSkip(path) {
+				return nil
+			}
+			scannedFiles = append(scannedFiles, path)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walking %s: %w", scanPath, err)
+		}
+	} else {
+		scannedFiles = []string{scanPath}
+	}
+
+	statement, err := attest.NewStatement(scannedFiles, attestationPredicateType, results)
+	if err != nil {
+		return nil, err
+	}
+
+	var signer attest.Signer
+	if keyless {
+		signer = attest.KeylessSigner{}
+	} else {
+		if keyPath == "" {
+			return nil, fmt.Errorf("-attest requires -key <path> or -keyless")
+		}
+		key, err := attest.LoadEd25519KeyFile(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		signer = attest.Ed25519Signer{KeyID: keyPath, Key: key}
+	}
+
+	return attest.Sign(statement, signer)
 }